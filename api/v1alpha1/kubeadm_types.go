@@ -0,0 +1,158 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	bootstraptokenv1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/bootstraptoken/v1"
+)
+
+// KubeadmConfigChecksumDependant is implemented by every status entry that tracks the checksum
+// of a resource applied to the tenant control plane as part of a kubeadm phase.
+type KubeadmConfigChecksumDependant interface {
+	GetChecksum() string
+	SetChecksum(checksum string)
+	GetObservedChecksum() string
+	SetObservedChecksum(checksum string)
+}
+
+// KubeadmPhaseStatus tracks the checksum of the last kubeadm resource applied to the tenant
+// control plane for a single kubeadm phase, alongside the checksum last observed on the tenant
+// cluster itself, used for drift detection.
+type KubeadmPhaseStatus struct {
+	// Checksum of the last applied resource for this phase.
+	Checksum string `json:"checksum,omitempty"`
+	// ObservedChecksum is the checksum of the resource as last observed on the tenant cluster.
+	// It is only populated when spec.kubeadm.driftPolicy is Enforce.
+	// +optional
+	ObservedChecksum string `json:"observedChecksum,omitempty"`
+}
+
+func (s *KubeadmPhaseStatus) GetChecksum() string {
+	return s.Checksum
+}
+
+func (s *KubeadmPhaseStatus) SetChecksum(checksum string) {
+	s.Checksum = checksum
+}
+
+func (s *KubeadmPhaseStatus) GetObservedChecksum() string {
+	return s.ObservedChecksum
+}
+
+func (s *KubeadmPhaseStatus) SetObservedChecksum(checksum string) {
+	s.ObservedChecksum = checksum
+}
+
+// KubeadmPhasesStatus reports the status of every kubeadm init phase applied by Kamaji against a
+// TenantControlPlane.
+type KubeadmPhasesStatus struct {
+	// UploadConfigKubeadm tracks the upload of the kubeadm-config ConfigMap.
+	UploadConfigKubeadm KubeadmPhaseStatus `json:"uploadConfigKubeadm,omitempty"`
+	// UploadConfigKubelet tracks the upload of the kubelet-config ConfigMap.
+	UploadConfigKubelet KubeadmPhaseStatus `json:"uploadConfigKubelet,omitempty"`
+	// AddonCoreDNS tracks the installation of the CoreDNS addon.
+	AddonCoreDNS KubeadmPhaseStatus `json:"addonCoreDNS,omitempty"`
+	// AddonKubeProxy tracks the installation of the kube-proxy addon.
+	AddonKubeProxy KubeadmPhaseStatus `json:"addonKubeProxy,omitempty"`
+	// BootstrapToken tracks the bootstrap token Secrets created on the tenant control plane.
+	BootstrapToken BootstrapTokenPhaseStatus `json:"bootstrapToken,omitempty"`
+}
+
+// BootstrapTokenStatus reports a single active bootstrap token known to Kamaji.
+type BootstrapTokenStatus struct {
+	// ID is the public identifier of the token, as used in the `bootstrap-token-<id>` Secret name.
+	ID string `json:"id"`
+	// Expires is the expiration timestamp of the token.
+	// +optional
+	Expires *metav1.Time `json:"expires,omitempty"`
+}
+
+// BootstrapTokenPhaseStatus tracks the bootstrap tokens Kamaji is maintaining on the tenant
+// control plane, alongside the checksum of the last reconciliation.
+type BootstrapTokenPhaseStatus struct {
+	KubeadmPhaseStatus `json:",inline"`
+	// Tokens reports the bootstrap tokens currently active on the tenant control plane, so that
+	// external join controllers can observe their IDs and expirations.
+	// +optional
+	Tokens []BootstrapTokenStatus `json:"tokens,omitempty"`
+}
+
+// KubeadmAddonSpec allows enabling/disabling a kubeadm-managed addon and overriding its image
+// and replica count.
+type KubeadmAddonSpec struct {
+	// Enabled defines whether the addon should be installed by Kamaji. Defaults to true when unset,
+	// both at the API server (CRD schema default) and for TenantControlPlanes built without going
+	// through API server admission, such as in tests or controller-side code: use IsEnabled rather
+	// than reading this field directly.
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Image overrides the default image used to install the addon.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Replicas overrides the default replica count of the addon, when applicable.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// IsEnabled reports whether the addon should be installed, defaulting to true when Enabled is unset.
+func (s KubeadmAddonSpec) IsEnabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
+
+// KubeadmAddonsSpec groups the addons installed by Kamaji as part of the kubeadm init phases.
+type KubeadmAddonsSpec struct {
+	// CoreDNS controls the installation of the CoreDNS addon.
+	// +optional
+	CoreDNS KubeadmAddonSpec `json:"coreDNS,omitempty"`
+	// KubeProxy controls the installation of the kube-proxy addon.
+	// Disable it when bringing a CNI, such as Cilium, running in kube-proxy replacement mode.
+	// +optional
+	KubeProxy KubeadmAddonSpec `json:"kubeProxy,omitempty"`
+}
+
+// DriftPolicy controls how Kamaji reacts to a tenant admin mutating a resource it manages
+// directly on the tenant cluster.
+type DriftPolicy string
+
+const (
+	// DriftPolicyIgnore never re-applies a kubeadm phase based on tenant-side changes: only a
+	// change to the desired configuration, or a bootstrap token entering its rotation grace
+	// period, triggers a re-upload. This is the default.
+	DriftPolicyIgnore DriftPolicy = "Ignore"
+	// DriftPolicyEnforce re-applies a kubeadm phase whenever the live tenant-side resource no
+	// longer matches what Kamaji last applied, in addition to desired configuration changes.
+	DriftPolicyEnforce DriftPolicy = "Enforce"
+)
+
+// KubeadmSpec defines the user-facing configuration of the kubeadm init phases run by Kamaji
+// against a TenantControlPlane.
+type KubeadmSpec struct {
+	// Addons controls the optional kubeadm addons installed by Kamaji.
+	// +optional
+	Addons KubeadmAddonsSpec `json:"addons,omitempty"`
+	// BootstrapTokens lists the bootstrap tokens Kamaji maintains on the tenant control plane.
+	// Each entry accepts the same fields as kubeadm's own BootstrapToken (ttl, expires, usages,
+	// groups, description). Usages defaults to "signing,authentication" and groups defaults to
+	// "system:bootstrappers:kubeadm:default-node-token" when left empty.
+	// +optional
+	BootstrapTokens []bootstraptokenv1.BootstrapToken `json:"bootstrapTokens,omitempty"`
+	// BootstrapTokenRotationGracePeriod is how long before expiration Kamaji issues a replacement
+	// bootstrap token. Defaults to 1h.
+	// +optional
+	BootstrapTokenRotationGracePeriod *metav1.Duration `json:"bootstrapTokenRotationGracePeriod,omitempty"`
+	// DriftPolicy controls whether Kamaji re-applies a kubeadm phase when a tenant admin mutates
+	// the underlying resource directly on the tenant cluster. Defaults to Ignore.
+	// +kubebuilder:validation:Enum=Ignore;Enforce
+	// +kubebuilder:default=Ignore
+	// +optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+	// ExtraPhases lists additional, site-specific kubeadm phases to run after the built-in ones,
+	// such as uploading a custom audit policy ConfigMap or seeding a ClusterConfiguration patch.
+	// Each entry must match the name a phase was registered under via resources.Register, which
+	// requires compiling the phase into a custom Kamaji binary: phases are not loaded from plugins.
+	// +optional
+	ExtraPhases []string `json:"extraPhases,omitempty"`
+}
@@ -0,0 +1,28 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import "testing"
+
+func TestKubeadmAddonSpecIsEnabled(t *testing.T) {
+	enabled, disabled := true, false
+
+	tests := []struct {
+		name string
+		spec KubeadmAddonSpec
+		want bool
+	}{
+		{name: "unset defaults to enabled", spec: KubeadmAddonSpec{}, want: true},
+		{name: "explicitly enabled", spec: KubeadmAddonSpec{Enabled: &enabled}, want: true},
+		{name: "explicitly disabled", spec: KubeadmAddonSpec{Enabled: &disabled}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.spec.IsEnabled(); got != tt.want {
+				t.Errorf("IsEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TenantControlPlaneSpec defines the desired state of a TenantControlPlane.
+type TenantControlPlaneSpec struct {
+	// Kubeadm configures the kubeadm init phases run by Kamaji against this TenantControlPlane.
+	// +optional
+	Kubeadm KubeadmSpec `json:"kubeadm,omitempty"`
+}
+
+// TenantControlPlaneStatus defines the observed state of a TenantControlPlane.
+type TenantControlPlaneStatus struct {
+	// KubeadmPhase reports the status of the kubeadm init phases applied to this TenantControlPlane.
+	KubeadmPhase KubeadmPhasesStatus `json:"kubeadmPhase,omitempty"`
+	// ControlPlaneEndpoint is the address, reachable by tenant worker nodes, of the tenant API server.
+	ControlPlaneEndpoint string `json:"controlPlaneEndpoint,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TenantControlPlane is the Schema for the tenantcontrolplanes API.
+type TenantControlPlane struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantControlPlaneSpec   `json:"spec,omitempty"`
+	Status TenantControlPlaneStatus `json:"status,omitempty"`
+}
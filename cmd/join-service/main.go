@@ -0,0 +1,95 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/resources/joinservice"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = kamajiv1alpha1.AddToScheme(scheme)
+}
+
+func main() {
+	var (
+		address    string
+		caCertFile string
+		certFile   string
+		keyFile    string
+	)
+
+	flag.StringVar(&address, "address", ":8443", "address the join-service listens on")
+	flag.StringVar(&caCertFile, "client-ca-file", "", "CA used to verify the mTLS client certificates presented by joining nodes")
+	flag.StringVar(&certFile, "tls-cert-file", "", "TLS certificate served by the join-service")
+	flag.StringVar(&keyFile, "tls-key-file", "", "TLS private key served by the join-service")
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New())
+
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to load the management cluster kubeconfig: %v\n", err)
+		os.Exit(1)
+	}
+
+	managementClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to build the management cluster client: %v\n", err)
+		os.Exit(1)
+	}
+
+	tlsConfig, err := buildTLSConfig(caCertFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to build the TLS configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	server := &http.Server{
+		Addr:      address,
+		Handler:   joinservice.NewServer(managementClient),
+		TLSConfig: tlsConfig,
+	}
+
+	if err := server.ListenAndServeTLS(certFile, keyFile); err != nil && err != http.ErrServerClosed {
+		fmt.Fprintf(os.Stderr, "join-service terminated: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// buildTLSConfig requires and verifies a client certificate signed by the operator-managed CA, so
+// bootstrap tokens are never handed to unattested callers.
+func buildTLSConfig(caCertFile string) (*tls.Config, error) {
+	caCert, err := os.ReadFile(caCertFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the client CA file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("%s does not contain a valid PEM certificate", caCertFile)
+	}
+
+	return &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeadm
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/addons/dns"
+	"k8s.io/kubernetes/cmd/kubeadm/app/phases/addons/proxy"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// UploadCoreDNSAddon installs the CoreDNS addon against the tenant control plane, mirroring the
+// behaviour of `kubeadm init phase addon coredns`, and then applies the user-provided image and
+// replica overrides on top of it.
+func UploadCoreDNSAddon(client clientset.Interface, config *Configuration, spec kamajiv1alpha1.KubeadmAddonSpec) ([]byte, error) {
+	if err := dns.EnsureDNSAddon(&config.ClusterConfiguration, client); err != nil {
+		return nil, err
+	}
+
+	return nil, overrideDeployment(client, metav1.NamespaceSystem, "coredns", spec)
+}
+
+// UploadKubeProxyAddon installs the kube-proxy addon against the tenant control plane, mirroring
+// the behaviour of `kubeadm init phase addon kube-proxy`, and then applies the user-provided
+// image override on top of it. kube-proxy runs as a DaemonSet, so replica overrides are ignored.
+func UploadKubeProxyAddon(client clientset.Interface, config *Configuration, spec kamajiv1alpha1.KubeadmAddonSpec) ([]byte, error) {
+	if err := proxy.EnsureProxyAddon(&config.InitConfiguration, &config.ClusterConfiguration, client); err != nil {
+		return nil, err
+	}
+
+	return nil, overrideDaemonSetImage(client, metav1.NamespaceSystem, "kube-proxy", spec)
+}
+
+func overrideDeployment(client clientset.Interface, namespace, name string, spec kamajiv1alpha1.KubeadmAddonSpec) error {
+	if spec.Image == "" && spec.Replicas == nil {
+		return nil
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if spec.Image != "" {
+		deployment.Spec.Template.Spec.Containers[0].Image = spec.Image
+	}
+
+	if spec.Replicas != nil {
+		deployment.Spec.Replicas = spec.Replicas
+	}
+
+	_, err = client.AppsV1().Deployments(namespace).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+
+	return err
+}
+
+func overrideDaemonSetImage(client clientset.Interface, namespace, name string, spec kamajiv1alpha1.KubeadmAddonSpec) error {
+	if spec.Image == "" {
+		return nil
+	}
+
+	daemonSet, err := client.AppsV1().DaemonSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	daemonSet.Spec.Template.Spec.Containers[0].Image = spec.Image
+
+	_, err = client.AppsV1().DaemonSets(namespace).Update(context.TODO(), daemonSet, metav1.UpdateOptions{})
+
+	return err
+}
@@ -0,0 +1,103 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeadm
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func newDeployment(namespace, name, image string, replicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: name, Image: image}}},
+			},
+		},
+	}
+}
+
+func newDaemonSet(namespace, name, image string) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: name, Image: image}}},
+			},
+		},
+	}
+}
+
+func TestOverrideDeployment(t *testing.T) {
+	t.Run("no-op when neither image nor replicas are overridden", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newDeployment(metav1.NamespaceSystem, "coredns", "coredns:v1", 2))
+
+		if err := overrideDeployment(client, metav1.NamespaceSystem, "coredns", kamajiv1alpha1.KubeadmAddonSpec{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		deployment, _ := client.AppsV1().Deployments(metav1.NamespaceSystem).Get(context.TODO(), "coredns", metav1.GetOptions{})
+		if deployment.Spec.Template.Spec.Containers[0].Image != "coredns:v1" || *deployment.Spec.Replicas != 2 {
+			t.Errorf("expected the deployment to be untouched, got %+v", deployment.Spec)
+		}
+	})
+
+	t.Run("overrides image and replicas", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newDeployment(metav1.NamespaceSystem, "coredns", "coredns:v1", 2))
+
+		spec := kamajiv1alpha1.KubeadmAddonSpec{Image: "coredns:v2", Replicas: int32Ptr(3)}
+		if err := overrideDeployment(client, metav1.NamespaceSystem, "coredns", spec); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		deployment, _ := client.AppsV1().Deployments(metav1.NamespaceSystem).Get(context.TODO(), "coredns", metav1.GetOptions{})
+		if deployment.Spec.Template.Spec.Containers[0].Image != "coredns:v2" {
+			t.Errorf("expected the image to be overridden, got %q", deployment.Spec.Template.Spec.Containers[0].Image)
+		}
+
+		if *deployment.Spec.Replicas != 3 {
+			t.Errorf("expected replicas to be overridden, got %d", *deployment.Spec.Replicas)
+		}
+	})
+}
+
+func TestOverrideDaemonSetImage(t *testing.T) {
+	t.Run("no-op when the image is not overridden", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newDaemonSet(metav1.NamespaceSystem, "kube-proxy", "kube-proxy:v1"))
+
+		if err := overrideDaemonSetImage(client, metav1.NamespaceSystem, "kube-proxy", kamajiv1alpha1.KubeadmAddonSpec{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		daemonSet, _ := client.AppsV1().DaemonSets(metav1.NamespaceSystem).Get(context.TODO(), "kube-proxy", metav1.GetOptions{})
+		if daemonSet.Spec.Template.Spec.Containers[0].Image != "kube-proxy:v1" {
+			t.Errorf("expected the daemonset to be untouched, got %q", daemonSet.Spec.Template.Spec.Containers[0].Image)
+		}
+	})
+
+	t.Run("overrides the image", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newDaemonSet(metav1.NamespaceSystem, "kube-proxy", "kube-proxy:v1"))
+
+		spec := kamajiv1alpha1.KubeadmAddonSpec{Image: "kube-proxy:v2"}
+		if err := overrideDaemonSetImage(client, metav1.NamespaceSystem, "kube-proxy", spec); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		daemonSet, _ := client.AppsV1().DaemonSets(metav1.NamespaceSystem).Get(context.TODO(), "kube-proxy", metav1.GetOptions{})
+		if daemonSet.Spec.Template.Spec.Containers[0].Image != "kube-proxy:v2" {
+			t.Errorf("expected the image to be overridden, got %q", daemonSet.Spec.Template.Spec.Containers[0].Image)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package joinservice
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/http"
+)
+
+// Attestor verifies that an incoming join request is allowed to receive a bootstrap token for the
+// given TenantControlPlane, and returns a non-nil error when the caller could not be verified.
+// Implementations are pluggable so downstream users can swap mTLS for a cloud-provider
+// node-identity check without touching the join-service wiring.
+type Attestor interface {
+	Attest(r *http.Request, namespace, tenantControlPlane string) error
+}
+
+// MutualTLSAttestor is the default Attestor: it trusts any caller presenting a client certificate
+// verified against the operator-managed CA configured on the HTTP server's tls.Config, and
+// requires the certificate's Common Name to match the requested TenantControlPlane's namespace and
+// name. Name alone is not enough: TenantControlPlane names are only unique within their namespace,
+// so two tenants in different namespaces can share a name.
+type MutualTLSAttestor struct{}
+
+func (MutualTLSAttestor) Attest(r *http.Request, namespace, tenantControlPlane string) error {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return fmt.Errorf("join request is missing a client certificate")
+	}
+
+	cert := r.TLS.PeerCertificates[0]
+	if err := verifyCommonName(cert, namespace, tenantControlPlane); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// tenantControlPlaneCommonName is the client certificate Common Name a caller must present to be
+// attested for the given TenantControlPlane.
+func tenantControlPlaneCommonName(namespace, tenantControlPlane string) string {
+	return namespace + "/" + tenantControlPlane
+}
+
+func verifyCommonName(cert *x509.Certificate, namespace, tenantControlPlane string) error {
+	want := tenantControlPlaneCommonName(namespace, tenantControlPlane)
+	if cert.Subject.CommonName != want {
+		return fmt.Errorf("client certificate common name %q is not authorized for tenant control plane %q", cert.Subject.CommonName, want)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package joinservice
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"testing"
+)
+
+func requestWithCommonName(cn string) *http.Request {
+	r := &http.Request{}
+	if cn == "" {
+		return r
+	}
+
+	r.TLS = &tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: cn}}},
+	}
+
+	return r
+}
+
+func TestMutualTLSAttestorAttest(t *testing.T) {
+	attestor := MutualTLSAttestor{}
+
+	t.Run("rejects a request without a client certificate", func(t *testing.T) {
+		if err := attestor.Attest(requestWithCommonName(""), "team1", "tenant-a"); err == nil {
+			t.Error("expected an error for a missing client certificate")
+		}
+	})
+
+	t.Run("accepts a certificate matching namespace and name", func(t *testing.T) {
+		r := requestWithCommonName(tenantControlPlaneCommonName("team1", "tenant-a"))
+
+		if err := attestor.Attest(r, "team1", "tenant-a"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("rejects a certificate minted for the same name in a different namespace", func(t *testing.T) {
+		r := requestWithCommonName(tenantControlPlaneCommonName("team1", "tenant-a"))
+
+		if err := attestor.Attest(r, "team2", "tenant-a"); err == nil {
+			t.Error("expected the cross-namespace request to be rejected")
+		}
+	})
+}
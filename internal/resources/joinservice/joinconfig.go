@@ -0,0 +1,31 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package joinservice
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	bootstraptokenv1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/bootstraptoken/v1"
+	kubeadmapiv1beta3 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta3"
+	"sigs.k8s.io/yaml"
+)
+
+// renderJoinConfiguration builds the kubeadm JoinConfiguration a worker node needs to join the
+// tenant control plane, and marshals it to YAML.
+func renderJoinConfiguration(token *bootstraptokenv1.BootstrapToken, apiServerEndpoint, caCertHash string) ([]byte, error) {
+	joinConfiguration := kubeadmapiv1beta3.JoinConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kubeadmapiv1beta3.SchemeGroupVersion.String(),
+			Kind:       "JoinConfiguration",
+		},
+		Discovery: kubeadmapiv1beta3.Discovery{
+			BootstrapToken: &kubeadmapiv1beta3.BootstrapTokenDiscovery{
+				Token:             token.Token.String(),
+				APIServerEndpoint: apiServerEndpoint,
+				CACertHashes:      []string{caCertHash},
+			},
+		},
+	}
+
+	return yaml.Marshal(joinConfiguration)
+}
@@ -0,0 +1,30 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package joinservice
+
+import (
+	"strings"
+	"testing"
+
+	bootstraptokenv1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/bootstraptoken/v1"
+)
+
+func TestRenderJoinConfiguration(t *testing.T) {
+	token := &bootstraptokenv1.BootstrapToken{
+		Token: &bootstraptokenv1.BootstrapTokenString{ID: "abcdef", Secret: "0123456789abcdef"},
+	}
+
+	rendered, err := renderJoinConfiguration(token, "https://tenant.example.com:6443", "sha256:deadbeef")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	yaml := string(rendered)
+
+	for _, want := range []string{"abcdef.0123456789abcdef", "https://tenant.example.com:6443", "sha256:deadbeef", "kind: JoinConfiguration"} {
+		if !strings.Contains(yaml, want) {
+			t.Errorf("expected rendered join configuration to contain %q, got:\n%s", want, yaml)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package joinservice
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	bootstraptokenv1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/bootstraptoken/v1"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pubkeypin"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/resources"
+)
+
+// defaultJoinTokenTTL is how long the bootstrap token minted for a join request stays valid: just
+// long enough for the worker to complete `kubeadm join`.
+const defaultJoinTokenTTL = 5 * time.Minute
+
+// adminKubeconfigSecretKey is the key of the tenant admin kubeconfig inside its Secret.
+const adminKubeconfigSecretKey = "admin.conf"
+
+// Server exposes, per TenantControlPlane, an authenticated endpoint that worker nodes can call to
+// obtain a ready-to-use kubeadm JoinConfiguration. It mirrors the join-service pattern used by
+// Constellation, adapted to Kamaji's multi-tenant control planes.
+type Server struct {
+	// Client talks to the management cluster hosting the TenantControlPlane resources.
+	Client client.Client
+	// Attestor verifies that the caller is allowed to join the requested TenantControlPlane.
+	Attestor Attestor
+}
+
+// NewServer returns a Server gated by the default mTLS Attestor.
+func NewServer(c client.Client) *Server {
+	return &Server{Client: c, Attestor: MutualTLSAttestor{}}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := log.FromContext(ctx)
+
+	namespace, name := r.URL.Query().Get("namespace"), r.URL.Query().Get("name")
+	if namespace == "" || name == "" {
+		http.Error(w, "namespace and name query parameters are required", http.StatusBadRequest)
+
+		return
+	}
+
+	if err := s.Attestor.Attest(r, namespace, name); err != nil {
+		logger.Error(err, "join request failed attestation", "namespace", namespace, "name", name)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+		return
+	}
+
+	var tenantControlPlane kamajiv1alpha1.TenantControlPlane
+	if err := s.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &tenantControlPlane); err != nil {
+		logger.Error(err, "unable to retrieve the TenantControlPlane", "namespace", namespace, "name", name)
+		http.Error(w, "tenant control plane not found", http.StatusNotFound)
+
+		return
+	}
+
+	joinConfig, err := s.renderJoinConfiguration(ctx, &tenantControlPlane)
+	if err != nil {
+		logger.Error(err, "unable to render the join configuration", "namespace", namespace, "name", name)
+		http.Error(w, "unable to render the join configuration", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(joinConfig)
+}
+
+func (s *Server) renderJoinConfiguration(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) ([]byte, error) {
+	tenantClient, err := s.tenantClientset(ctx, tenantControlPlane)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build the tenant clientset: %w", err)
+	}
+
+	token, err := s.mintJoinToken(tenantClient)
+	if err != nil {
+		return nil, fmt.Errorf("unable to mint the bootstrap token: %w", err)
+	}
+
+	caCertHash, err := s.caCertHash(ctx, tenantControlPlane)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute the CA certificate hash: %w", err)
+	}
+
+	return renderJoinConfiguration(token, tenantControlPlane.Status.ControlPlaneEndpoint, caCertHash)
+}
+
+func (s *Server) mintJoinToken(tenantClient clientset.Interface) (*bootstraptokenv1.BootstrapToken, error) {
+	ttl := metav1.Duration{Duration: defaultJoinTokenTTL}
+
+	token := bootstraptokenv1.BootstrapToken{
+		TTL: &ttl,
+	}
+	resources.EnrichBootstrapToken(&token)
+
+	config := &kubeadm.Configuration{}
+	config.InitConfiguration.BootstrapTokens = []bootstraptokenv1.BootstrapToken{token}
+
+	if err := kubeadm.BootstrapToken(tenantClient, config); err != nil {
+		return nil, err
+	}
+
+	return &config.InitConfiguration.BootstrapTokens[0], nil
+}
+
+func (s *Server) caCertHash(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (string, error) {
+	var secret corev1.Secret
+
+	key := client.ObjectKey{Namespace: tenantControlPlane.Namespace, Name: fmt.Sprintf("%s-ca", tenantControlPlane.Name)}
+	if err := s.Client.Get(ctx, key, &secret); err != nil {
+		return "", err
+	}
+
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		return "", fmt.Errorf("secret %s does not contain a valid CA certificate", key)
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", err
+	}
+
+	return pubkeypin.Generate(cert), nil
+}
+
+func (s *Server) tenantClientset(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (clientset.Interface, error) {
+	var secret corev1.Secret
+
+	key := client.ObjectKey{Namespace: tenantControlPlane.Namespace, Name: fmt.Sprintf("%s-admin-kubeconfig", tenantControlPlane.Name)}
+	if err := s.Client.Get(ctx, key, &secret); err != nil {
+		return nil, err
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(secret.Data[adminKubeconfigSecretKey])
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.NewForConfig(restConfig)
+}
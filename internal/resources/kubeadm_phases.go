@@ -5,8 +5,15 @@ package resources
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"sort"
+	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	bootstraputil "k8s.io/cluster-bootstrap/token/util"
 	clientset "k8s.io/client-go/kubernetes"
 	bootstraptokenv1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/bootstraptoken/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -18,23 +25,388 @@ import (
 	"github.com/clastix/kamaji/internal/resources/utils"
 )
 
-type kubeadmPhase int
+// defaultBootstrapTokenRotationGracePeriod is how long before expiration Kamaji issues a
+// replacement bootstrap token, when spec.kubeadm.bootstrapTokenRotationGracePeriod is unset.
+const defaultBootstrapTokenRotationGracePeriod = time.Hour
 
+var (
+	defaultBootstrapTokenUsages = []string{"signing", "authentication"}
+	defaultBootstrapTokenGroups = []string{"system:bootstrappers:kubeadm:default-node-token"}
+)
+
+// Well-known names of the kubeadm phases built into Kamaji. Custom phases registered via
+// Register must use a different name.
 const (
-	PhaseUploadConfigKubeadm kubeadmPhase = iota
-	PhaseUploadConfigKubelet
-	PhaseBootstrapToken
+	PhaseNameUploadConfigKubeadm = "PhaseUploadConfigKubeadm"
+	PhaseNameUploadConfigKubelet = "PhaseUploadConfigKubelet"
+	PhaseNameAddonCoreDNS        = "PhaseAddonCoreDNS"
+	PhaseNameAddonKubeProxy      = "PhaseAddonKubeProxy"
+	PhaseNameBootstrapToken      = "PhaseBootstrapToken"
 )
 
-func (d kubeadmPhase) String() string {
-	return [...]string{"PhaseUploadConfigKubeadm", "PhaseUploadConfigKubelet", "PhaseAddonCoreDNS", "PhaseAddonKubeProxy", "PhaseBootstrapToken"}[d]
+// Phase is a single step of the kubeadm init pipeline Kamaji runs against a TenantControlPlane.
+// Downstream users compiling their own Kamaji binary can implement Phase and Register it under a
+// unique name, then reference that name from spec.kubeadm.extraPhases to extend the init pipeline
+// without patching this package.
+type Phase interface {
+	// Name uniquely identifies the phase, and is reported in logs.
+	Name() string
+	// Run executes the phase against the tenant API server.
+	Run(clientset.Interface, *kubeadm.Configuration) ([]byte, error)
+	// Status returns the TenantControlPlane status entry tracking this phase's checksum.
+	Status(*kamajiv1alpha1.TenantControlPlane) kamajiv1alpha1.KubeadmConfigChecksumDependant
+}
+
+// DriftCheckablePhase is implemented by phases that can hash the resource they manage as observed
+// on the tenant cluster, so KubeadmPhase can detect tenant-side drift for them. Phases that don't
+// implement it are simply skipped by drift detection.
+type DriftCheckablePhase interface {
+	Phase
+	ObservedChecksum(ctx context.Context, tenantClient clientset.Interface) (string, error)
+}
+
+// RotationAwarePhase is implemented by phases that must be re-applied purely because time has
+// passed, even though neither the desired spec nor the tenant-side resource changed, such as
+// bootstrapTokenPhase rotating a token that has entered its expiry grace window. Phases that don't
+// implement it are never considered due for a time-based re-apply.
+type RotationAwarePhase interface {
+	Phase
+	IsRotationDue() bool
+}
+
+// StatusWriterPhase is implemented by phases that need to persist extra fields into their status
+// entry after a successful Run, beyond the checksum KubeadmPhase already tracks for every phase.
+// Status() itself must stay read-only, since it is also called by isStatusEqual/isDrifted before
+// Run has a chance to run; WriteStatus is only invoked from UpdateTenantControlPlaneStatus, once
+// Run has executed. Phases that don't implement it have nothing extra to persist.
+type StatusWriterPhase interface {
+	Phase
+	WriteStatus(tenantControlPlane *kamajiv1alpha1.TenantControlPlane)
+}
+
+var phaseRegistry = map[string]func() Phase{}
+
+// Register makes a Phase factory available under name, so it can be resolved by the TCP
+// reconciler from spec.kubeadm.extraPhases. Register is typically called from an init() function,
+// either in this package for the built-ins, or in a downstream package compiled into a custom
+// Kamaji binary. Registering the same name twice overwrites the previous factory.
+func Register(name string, factory func() Phase) {
+	phaseRegistry[name] = factory
+}
+
+// NewPhase resolves a registered Phase factory by name.
+func NewPhase(name string) (Phase, error) {
+	factory, ok := phaseRegistry[name]
+	if !ok {
+		return nil, fmt.Errorf("no kubeadm phase is registered under the name %q", name)
+	}
+
+	return factory(), nil
+}
+
+func init() {
+	Register(PhaseNameUploadConfigKubeadm, func() Phase { return &uploadKubeadmConfigPhase{} })
+	Register(PhaseNameUploadConfigKubelet, func() Phase { return &uploadKubeletConfigPhase{} })
+	// KubeadmAddonSpec.IsEnabled already defaults to true on the zero value, so the registry
+	// factories need nothing beyond an empty AddonSpec.
+	Register(PhaseNameAddonCoreDNS, func() Phase { return &addonCoreDNSPhase{} })
+	Register(PhaseNameAddonKubeProxy, func() Phase { return &addonKubeProxyPhase{} })
+	Register(PhaseNameBootstrapToken, func() Phase { return &bootstrapTokenPhase{} })
+}
+
+type uploadKubeadmConfigPhase struct{}
+
+func (*uploadKubeadmConfigPhase) Name() string { return PhaseNameUploadConfigKubeadm }
+
+func (*uploadKubeadmConfigPhase) Run(client clientset.Interface, config *kubeadm.Configuration) ([]byte, error) {
+	return kubeadm.UploadKubeadmConfig(client, config)
+}
+
+func (*uploadKubeadmConfigPhase) Status(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) kamajiv1alpha1.KubeadmConfigChecksumDependant {
+	return &tenantControlPlane.Status.KubeadmPhase.UploadConfigKubeadm
+}
+
+func (*uploadKubeadmConfigPhase) ObservedChecksum(ctx context.Context, tenantClient clientset.Interface) (string, error) {
+	return configMapChecksum(ctx, tenantClient, "kubeadm-config")
+}
+
+type uploadKubeletConfigPhase struct{}
+
+func (*uploadKubeletConfigPhase) Name() string { return PhaseNameUploadConfigKubelet }
+
+func (*uploadKubeletConfigPhase) Run(client clientset.Interface, config *kubeadm.Configuration) ([]byte, error) {
+	return kubeadm.UploadKubeletConfig(client, config)
+}
+
+func (*uploadKubeletConfigPhase) Status(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) kamajiv1alpha1.KubeadmConfigChecksumDependant {
+	return &tenantControlPlane.Status.KubeadmPhase.UploadConfigKubelet
+}
+
+func (*uploadKubeletConfigPhase) ObservedChecksum(ctx context.Context, tenantClient clientset.Interface) (string, error) {
+	return configMapChecksum(ctx, tenantClient, "kubelet-config")
+}
+
+// addonCoreDNSPhase installs the CoreDNS addon. AddonSpec is populated by the TCP reconciler from
+// spec.kubeadm.addons.coreDNS before the phase is run.
+type addonCoreDNSPhase struct {
+	AddonSpec kamajiv1alpha1.KubeadmAddonSpec
+}
+
+func (*addonCoreDNSPhase) Name() string { return PhaseNameAddonCoreDNS }
+
+func (p *addonCoreDNSPhase) Run(client clientset.Interface, config *kubeadm.Configuration) ([]byte, error) {
+	if !p.AddonSpec.IsEnabled() {
+		return nil, nil
+	}
+
+	return kubeadm.UploadCoreDNSAddon(client, config, p.AddonSpec)
+}
+
+func (*addonCoreDNSPhase) Status(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) kamajiv1alpha1.KubeadmConfigChecksumDependant {
+	return &tenantControlPlane.Status.KubeadmPhase.AddonCoreDNS
+}
+
+// addonKubeProxyPhase installs the kube-proxy addon. AddonSpec is populated by the TCP reconciler
+// from spec.kubeadm.addons.kubeProxy before the phase is run; leave it disabled when bringing a
+// CNI, such as Cilium, running in kube-proxy replacement mode.
+type addonKubeProxyPhase struct {
+	AddonSpec kamajiv1alpha1.KubeadmAddonSpec
+}
+
+func (*addonKubeProxyPhase) Name() string { return PhaseNameAddonKubeProxy }
+
+func (p *addonKubeProxyPhase) Run(client clientset.Interface, config *kubeadm.Configuration) ([]byte, error) {
+	if !p.AddonSpec.IsEnabled() {
+		return nil, nil
+	}
+
+	return kubeadm.UploadKubeProxyAddon(client, config, p.AddonSpec)
+}
+
+func (*addonKubeProxyPhase) Status(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) kamajiv1alpha1.KubeadmConfigChecksumDependant {
+	return &tenantControlPlane.Status.KubeadmPhase.AddonKubeProxy
+}
+
+// bootstrapTokenPhase maintains the bootstrap tokens declared under spec.kubeadm.bootstrapTokens,
+// rotating them as they approach expiration. RotationGracePeriod and ActiveTokens are populated by
+// the TCP reconciler before the phase is run.
+type bootstrapTokenPhase struct {
+	RotationGracePeriod time.Duration
+	ActiveTokens        []kamajiv1alpha1.BootstrapTokenStatus
+	tokens              []bootstraptokenv1.BootstrapToken
+}
+
+func (*bootstrapTokenPhase) Name() string { return PhaseNameBootstrapToken }
+
+func (p *bootstrapTokenPhase) Run(client clientset.Interface, config *kubeadm.Configuration) ([]byte, error) {
+	config.InitConfiguration.BootstrapTokens = p.rotateBootstrapTokens(client, config.InitConfiguration.BootstrapTokens)
+
+	bootstrapTokensEnrichment(config.InitConfiguration.BootstrapTokens)
+
+	p.tokens = config.InitConfiguration.BootstrapTokens
+
+	return nil, kubeadm.BootstrapToken(client, config)
+}
+
+// Status is also called read-only by isStatusEqual/isDrifted before Run ever executes, so it must
+// not write p.tokens into the TenantControlPlane: on a freshly-constructed phase p.tokens is still
+// nil, and doing so would wipe the persisted token list on every steady-state reconcile. WriteStatus
+// carries the equivalent write, and only runs once Run has actually produced fresh tokens.
+func (p *bootstrapTokenPhase) Status(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) kamajiv1alpha1.KubeadmConfigChecksumDependant {
+	return &tenantControlPlane.Status.KubeadmPhase.BootstrapToken
+}
+
+func (p *bootstrapTokenPhase) ObservedChecksum(ctx context.Context, tenantClient clientset.Interface) (string, error) {
+	return bootstrapTokenSecretsChecksum(ctx, tenantClient, p.ActiveTokens)
+}
+
+// WriteStatus persists the tokens minted by the last Run, so external join controllers can observe
+// their IDs and expirations. It is a no-op when Run has not executed yet, since p.tokens is only
+// populated there.
+func (p *bootstrapTokenPhase) WriteStatus(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) {
+	if p.tokens == nil {
+		return
+	}
+
+	tenantControlPlane.Status.KubeadmPhase.BootstrapToken.Tokens = bootstrapTokenStatuses(p.tokens)
+}
+
+// rotateBootstrapTokens resets the identity and expiration of every desired token that is within
+// the rotation grace period of expiring, and best-effort deletes its Secret on the tenant cluster.
+// bootstrapTokensEnrichment then mints a fresh ID and expiration for the reset entries while the
+// TTL, usages, groups and description the user declared are left untouched. Tokens that are not
+// yet due for rotation, or whose deletion fails, are left for the next reconcile.
+func (p *bootstrapTokenPhase) rotateBootstrapTokens(client clientset.Interface, bootstrapTokens []bootstraptokenv1.BootstrapToken) []bootstraptokenv1.BootstrapToken {
+	expiring := p.expiringTokenIDs()
+
+	for i := range bootstrapTokens {
+		bootstrapToken := &bootstrapTokens[i]
+
+		var id string
+		if bootstrapToken.Token != nil {
+			id = bootstrapToken.Token.ID
+		}
+
+		if _, isExpiring := expiring[id]; !isExpiring {
+			continue
+		}
+
+		secretName := bootstraputil.BootstrapTokenSecretName(id)
+		if err := client.CoreV1().Secrets(metav1.NamespaceSystem).Delete(context.TODO(), secretName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			continue
+		}
+
+		bootstrapToken.Token = nil
+		bootstrapToken.Expires = nil
+	}
+
+	return bootstrapTokens
+}
+
+// expiringTokenIDs returns the IDs of the active tokens that fall within the rotation grace period
+// of expiring, as of now.
+func (p *bootstrapTokenPhase) expiringTokenIDs() map[string]struct{} {
+	gracePeriod := p.RotationGracePeriod
+	if gracePeriod == 0 {
+		gracePeriod = defaultBootstrapTokenRotationGracePeriod
+	}
+
+	deadline := time.Now().Add(gracePeriod)
+
+	expiring := make(map[string]struct{}, len(p.ActiveTokens))
+	for _, active := range p.ActiveTokens {
+		if active.Expires == nil || active.Expires.Time.After(deadline) {
+			continue
+		}
+
+		expiring[active.ID] = struct{}{}
+	}
+
+	return expiring
+}
+
+// IsRotationDue reports whether any active token has entered its rotation grace period, so
+// KubeadmPhase.ShouldStatusBeUpdated can force a re-apply even when the desired spec and the
+// tenant-side resource are both unchanged.
+func (p *bootstrapTokenPhase) IsRotationDue() bool {
+	return len(p.expiringTokenIDs()) > 0
+}
+
+func bootstrapTokensEnrichment(bootstrapTokens []bootstraptokenv1.BootstrapToken) {
+	for i := range bootstrapTokens {
+		EnrichBootstrapToken(&bootstrapTokens[i])
+	}
+}
+
+// EnrichBootstrapToken fills in a bootstrap token's ID, usages, groups and expiration wherever the
+// caller left them unset, generating a fresh random ID when Token is nil. It is exported so callers
+// outside this package, such as the join-service, can mint ad-hoc tokens the same way the
+// bootstrapTokenPhase does.
+func EnrichBootstrapToken(bootstrapToken *bootstraptokenv1.BootstrapToken) {
+	if bootstrapToken.Token == nil {
+		bootstrapToken.Token = &bootstraptokenv1.BootstrapTokenString{}
+	}
+
+	// kubeadm requires the token ID and secret to be generated independently: the ID is the
+	// public part embedded in the `bootstrap-token-<id>` Secret name, and the secret is the
+	// private part never exposed outside the rendered token string.
+	if bootstrapToken.Token.ID == "" {
+		bootstrapToken.Token.ID = utils.RandomString(6)
+	}
+
+	if bootstrapToken.Token.Secret == "" {
+		bootstrapToken.Token.Secret = utils.RandomString(16)
+	}
+
+	if len(bootstrapToken.Usages) == 0 {
+		bootstrapToken.Usages = defaultBootstrapTokenUsages
+	}
+
+	if len(bootstrapToken.Groups) == 0 {
+		bootstrapToken.Groups = defaultBootstrapTokenGroups
+	}
+
+	if bootstrapToken.Expires == nil && bootstrapToken.TTL != nil {
+		expires := metav1.NewTime(time.Now().Add(bootstrapToken.TTL.Duration))
+		bootstrapToken.Expires = &expires
+	}
+}
+
+func bootstrapTokenStatuses(bootstrapTokens []bootstraptokenv1.BootstrapToken) []kamajiv1alpha1.BootstrapTokenStatus {
+	statuses := make([]kamajiv1alpha1.BootstrapTokenStatus, 0, len(bootstrapTokens))
+
+	for _, bootstrapToken := range bootstrapTokens {
+		var id string
+		if bootstrapToken.Token != nil {
+			id = bootstrapToken.Token.ID
+		}
+
+		statuses = append(statuses, kamajiv1alpha1.BootstrapTokenStatus{ID: id, Expires: bootstrapToken.Expires})
+	}
+
+	return statuses
+}
+
+func configMapChecksum(ctx context.Context, tenantClient clientset.Interface, name string) (string, error) {
+	configMap, err := tenantClient.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	return checksumOf(configMap.Data), nil
+}
+
+func bootstrapTokenSecretsChecksum(ctx context.Context, tenantClient clientset.Interface, activeTokens []kamajiv1alpha1.BootstrapTokenStatus) (string, error) {
+	data := map[string]string{}
+
+	for _, active := range activeTokens {
+		secret, err := tenantClient.CoreV1().Secrets(metav1.NamespaceSystem).Get(ctx, bootstraputil.BootstrapTokenSecretName(active.ID), metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+
+		for key, value := range secret.Data {
+			data[active.ID+"/"+key] = string(value)
+		}
+	}
+
+	return checksumOf(data), nil
+}
+
+// checksumOf returns a deterministic sha256 hex digest of a string-keyed map.
+func checksumOf(data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		hash.Write([]byte(key))
+		hash.Write([]byte(data[key]))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
 }
 
+// KubeadmPhase drives a single Phase through the CreateOrUpdate resource lifecycle: it runs the
+// phase against the tenant API server and tracks its checksum in the TenantControlPlane status.
 type KubeadmPhase struct {
-	Client   client.Client
-	Name     string
-	Phase    kubeadmPhase
+	Client client.Client
+	Name   string
+	Phase  Phase
+
 	checksum string
+
+	// TenantClient is used for drift detection: it reads the live resource managed by this phase
+	// back from the tenant cluster. Required when spec.kubeadm.driftPolicy is Enforce and Phase
+	// implements DriftCheckablePhase.
+	TenantClient clientset.Interface
+	// observedChecksum caches the checksum computed by the last drift check, so it can be
+	// persisted by UpdateTenantControlPlaneStatus without hitting the tenant cluster again.
+	observedChecksum string
 }
 
 func (r *KubeadmPhase) isStatusEqual(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
@@ -43,20 +415,65 @@ func (r *KubeadmPhase) isStatusEqual(tenantControlPlane *kamajiv1alpha1.TenantCo
 		return true
 	}
 
-	status, ok := i.(*kamajiv1alpha1.KubeadmPhaseStatus)
-	if !ok {
-		return false
-	}
-
-	return status.Checksum == r.checksum
+	return i.GetChecksum() == r.checksum
 }
 
 func (r *KubeadmPhase) SetKubeadmConfigChecksum(checksum string) {
 	r.checksum = checksum
 }
 
-func (r *KubeadmPhase) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return !r.isStatusEqual(tenantControlPlane)
+func (r *KubeadmPhase) ShouldStatusBeUpdated(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	desiredChanged := !r.isStatusEqual(tenantControlPlane)
+	// A RotationAwarePhase forces a re-apply purely because time has passed, regardless of the
+	// drift policy: an unchanged spec must not let an active token sail past its expiry.
+	rotationDue := r.isRotationDue()
+
+	if tenantControlPlane.Spec.Kubeadm.DriftPolicy != kamajiv1alpha1.DriftPolicyEnforce {
+		return desiredChanged || rotationDue
+	}
+
+	// isDrifted must run even when desiredChanged or rotationDue already forces a re-apply, since
+	// it is also responsible for refreshing r.observedChecksum so UpdateTenantControlPlaneStatus
+	// can persist it; skipping it here would leave status.ObservedChecksum stale until the next
+	// reconcile notices the drift on its own.
+	drifted := r.isDrifted(ctx, tenantControlPlane)
+
+	return desiredChanged || rotationDue || drifted
+}
+
+// isRotationDue reports whether r.Phase implements RotationAwarePhase and considers itself due for
+// a time-based re-apply. Phases that don't implement it are never considered due.
+func (r *KubeadmPhase) isRotationDue() bool {
+	rotationAware, ok := r.Phase.(RotationAwarePhase)
+
+	return ok && rotationAware.IsRotationDue()
+}
+
+// isDrifted hashes the resource this phase manages as it is currently observed on the tenant
+// cluster, and compares it against the last checksum Kamaji recorded for it. A mismatch means the
+// tenant admin mutated the resource out-of-band, so the phase must be re-applied. Phases that
+// don't implement DriftCheckablePhase are never considered drifted.
+func (r *KubeadmPhase) isDrifted(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	driftable, ok := r.Phase.(DriftCheckablePhase)
+	if !ok || r.TenantClient == nil {
+		return false
+	}
+
+	observed, err := driftable.ObservedChecksum(ctx, r.TenantClient)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "unable to compute the observed checksum for drift detection", "resource", r.GetName(), "phase", r.Phase.Name())
+
+		return false
+	}
+
+	r.observedChecksum = observed
+
+	status, err := r.GetStatus(tenantControlPlane)
+	if err != nil {
+		return false
+	}
+
+	return status.GetObservedChecksum() != observed
 }
 
 func (r *KubeadmPhase) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -72,40 +489,11 @@ func (r *KubeadmPhase) Define(context.Context, *kamajiv1alpha1.TenantControlPlan
 }
 
 func (r *KubeadmPhase) GetKubeadmFunction() (func(clientset.Interface, *kubeadm.Configuration) ([]byte, error), error) {
-	switch r.Phase {
-	case PhaseUploadConfigKubeadm:
-		return kubeadm.UploadKubeadmConfig, nil
-	case PhaseUploadConfigKubelet:
-		return kubeadm.UploadKubeletConfig, nil
-	case PhaseBootstrapToken:
-		return func(client clientset.Interface, config *kubeadm.Configuration) ([]byte, error) {
-			bootstrapTokensEnrichment(config.InitConfiguration.BootstrapTokens)
-
-			return nil, kubeadm.BootstrapToken(client, config)
-		}, nil
-	default:
-		return nil, fmt.Errorf("no available functionality for phase %s", r.Phase)
-	}
-}
-
-func bootstrapTokensEnrichment(bootstrapTokens []bootstraptokenv1.BootstrapToken) {
-	var bootstrapToken bootstraptokenv1.BootstrapToken
-	if len(bootstrapTokens) > 0 {
-		bootstrapToken = bootstrapTokens[0]
-	}
-
-	enrichBootstrapToken(&bootstrapToken)
-	bootstrapTokens[0] = bootstrapToken
-}
-
-func enrichBootstrapToken(bootstrapToken *bootstraptokenv1.BootstrapToken) {
-	if bootstrapToken.Token == nil {
-		bootstrapToken.Token = &bootstraptokenv1.BootstrapTokenString{}
+	if r.Phase == nil {
+		return nil, fmt.Errorf("no kubeadm phase configured for %s", r.GetName())
 	}
 
-	if bootstrapToken.Token.ID == "" {
-		bootstrapToken.Token.ID = fmt.Sprintf("%s.%s", utils.RandomString(6), utils.RandomString(16))
-	}
+	return r.Phase.Run, nil
 }
 
 func (r *KubeadmPhase) GetClient() client.Client {
@@ -121,7 +509,7 @@ func (r *KubeadmPhase) GetName() string {
 }
 
 func (r *KubeadmPhase) UpdateTenantControlPlaneStatus(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
-	logger := log.FromContext(ctx, "resource", r.GetName(), "phase", r.Phase.String())
+	logger := log.FromContext(ctx, "resource", r.GetName(), "phase", r.Phase.Name())
 
 	status, err := r.GetStatus(tenantControlPlane)
 	if err != nil {
@@ -132,24 +520,75 @@ func (r *KubeadmPhase) UpdateTenantControlPlaneStatus(ctx context.Context, tenan
 
 	status.SetChecksum(r.checksum)
 
+	if tenantControlPlane.Spec.Kubeadm.DriftPolicy == kamajiv1alpha1.DriftPolicyEnforce && r.observedChecksum != "" {
+		status.SetObservedChecksum(r.observedChecksum)
+	}
+
+	if writer, ok := r.Phase.(StatusWriterPhase); ok {
+		writer.WriteStatus(tenantControlPlane)
+	}
+
 	return nil
 }
 
 func (r *KubeadmPhase) GetStatus(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (kamajiv1alpha1.KubeadmConfigChecksumDependant, error) {
-	switch r.Phase {
-	case PhaseUploadConfigKubeadm:
-		return &tenantControlPlane.Status.KubeadmPhase.UploadConfigKubeadm, nil
-	case PhaseUploadConfigKubelet:
-		return &tenantControlPlane.Status.KubeadmPhase.UploadConfigKubelet, nil
-	case PhaseBootstrapToken:
-		return &tenantControlPlane.Status.KubeadmPhase.BootstrapToken, nil
-	default:
-		return nil, fmt.Errorf("%s is not a right kubeadm phase", r.Phase)
+	if r.Phase == nil {
+		return nil, fmt.Errorf("no kubeadm phase configured for %s", r.GetName())
 	}
+
+	return r.Phase.Status(tenantControlPlane), nil
 }
 
 func (r *KubeadmPhase) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
-	logger := log.FromContext(ctx, "resource", r.GetName(), "phase", r.Phase.String())
+	logger := log.FromContext(ctx, "resource", r.GetName(), "phase", r.Phase.Name())
 
 	return KubeadmPhaseCreate(ctx, r, logger, tenantControlPlane)
 }
+
+// NewKubeadmPhases resolves the kubeadm init pipeline configured for tenantControlPlane into the
+// ordered list of KubeadmPhase resources the TCP reconciler drives through CreateOrUpdate. It
+// copies every spec.kubeadm field a built-in phase needs onto that phase before wrapping it: addon
+// enablement/overrides onto the addon phases, and rotation grace period plus the currently active
+// token IDs onto the bootstrap token phase. Every name listed in spec.kubeadm.extraPhases is then
+// resolved from the phase registry and appended, in declaration order, so downstream binaries can
+// extend the pipeline without patching this function.
+func NewKubeadmPhases(c client.Client, tenantClient clientset.Interface, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) ([]*KubeadmPhase, error) {
+	kubeadmSpec := tenantControlPlane.Spec.Kubeadm
+
+	gracePeriod := defaultBootstrapTokenRotationGracePeriod
+	if kubeadmSpec.BootstrapTokenRotationGracePeriod != nil {
+		gracePeriod = kubeadmSpec.BootstrapTokenRotationGracePeriod.Duration
+	}
+
+	phases := []Phase{
+		&uploadKubeadmConfigPhase{},
+		&uploadKubeletConfigPhase{},
+		&addonCoreDNSPhase{AddonSpec: kubeadmSpec.Addons.CoreDNS},
+		&addonKubeProxyPhase{AddonSpec: kubeadmSpec.Addons.KubeProxy},
+		&bootstrapTokenPhase{
+			RotationGracePeriod: gracePeriod,
+			ActiveTokens:        tenantControlPlane.Status.KubeadmPhase.BootstrapToken.Tokens,
+		},
+	}
+
+	for _, name := range kubeadmSpec.ExtraPhases {
+		phase, err := NewPhase(name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve extra kubeadm phase %q: %w", name, err)
+		}
+
+		phases = append(phases, phase)
+	}
+
+	kubeadmPhases := make([]*KubeadmPhase, 0, len(phases))
+	for _, phase := range phases {
+		kubeadmPhases = append(kubeadmPhases, &KubeadmPhase{
+			Client:       c,
+			Name:         phase.Name(),
+			Phase:        phase,
+			TenantClient: tenantClient,
+		})
+	}
+
+	return kubeadmPhases, nil
+}
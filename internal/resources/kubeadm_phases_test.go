@@ -0,0 +1,333 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	bootstraptokenv1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/bootstraptoken/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/kubeadm"
+)
+
+// fakeDriftRotationPhase is a minimal Phase used to exercise KubeadmPhase.ShouldStatusBeUpdated's
+// branching between desired-checksum, drift, and rotation-due triggers, without needing a real
+// kubeadm phase or tenant cluster.
+type fakeDriftRotationPhase struct {
+	observedChecksum string
+	observedErr      error
+	rotationDue      bool
+}
+
+func (*fakeDriftRotationPhase) Name() string { return "Fake" }
+
+func (*fakeDriftRotationPhase) Run(clientset.Interface, *kubeadm.Configuration) ([]byte, error) {
+	return nil, nil
+}
+
+func (*fakeDriftRotationPhase) Status(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) kamajiv1alpha1.KubeadmConfigChecksumDependant {
+	return &tenantControlPlane.Status.KubeadmPhase.UploadConfigKubeadm
+}
+
+func (p *fakeDriftRotationPhase) ObservedChecksum(context.Context, clientset.Interface) (string, error) {
+	return p.observedChecksum, p.observedErr
+}
+
+func (p *fakeDriftRotationPhase) IsRotationDue() bool {
+	return p.rotationDue
+}
+
+func TestEnrichBootstrapToken(t *testing.T) {
+	t.Run("generates an ID and a secret independently", func(t *testing.T) {
+		bootstrapToken := bootstraptokenv1.BootstrapToken{}
+
+		EnrichBootstrapToken(&bootstrapToken)
+
+		if len(bootstrapToken.Token.ID) != 6 {
+			t.Errorf("expected a 6 character token ID, got %q", bootstrapToken.Token.ID)
+		}
+
+		if len(bootstrapToken.Token.Secret) != 16 {
+			t.Errorf("expected a 16 character token secret, got %q", bootstrapToken.Token.Secret)
+		}
+	})
+
+	t.Run("preserves a user-provided ID and secret", func(t *testing.T) {
+		bootstrapToken := bootstraptokenv1.BootstrapToken{
+			Token: &bootstraptokenv1.BootstrapTokenString{ID: "abcdef", Secret: "0123456789abcdef"},
+		}
+
+		EnrichBootstrapToken(&bootstrapToken)
+
+		if bootstrapToken.Token.ID != "abcdef" || bootstrapToken.Token.Secret != "0123456789abcdef" {
+			t.Errorf("expected the user-provided ID/secret to be preserved, got %+v", bootstrapToken.Token)
+		}
+	})
+
+	t.Run("defaults usages and groups", func(t *testing.T) {
+		bootstrapToken := bootstraptokenv1.BootstrapToken{}
+
+		EnrichBootstrapToken(&bootstrapToken)
+
+		if len(bootstrapToken.Usages) != 2 || bootstrapToken.Usages[0] != "signing" || bootstrapToken.Usages[1] != "authentication" {
+			t.Errorf("expected default usages, got %v", bootstrapToken.Usages)
+		}
+
+		if len(bootstrapToken.Groups) != 1 || bootstrapToken.Groups[0] != "system:bootstrappers:kubeadm:default-node-token" {
+			t.Errorf("expected default groups, got %v", bootstrapToken.Groups)
+		}
+	})
+
+	t.Run("computes Expires from TTL", func(t *testing.T) {
+		ttl := metav1.Duration{Duration: time.Hour}
+		bootstrapToken := bootstraptokenv1.BootstrapToken{TTL: &ttl}
+
+		before := time.Now()
+		EnrichBootstrapToken(&bootstrapToken)
+		after := time.Now()
+
+		if bootstrapToken.Expires == nil {
+			t.Fatal("expected Expires to be computed from TTL")
+		}
+
+		if bootstrapToken.Expires.Time.Before(before.Add(ttl.Duration)) || bootstrapToken.Expires.Time.After(after.Add(ttl.Duration)) {
+			t.Errorf("expected Expires to be roughly now+TTL, got %s", bootstrapToken.Expires.Time)
+		}
+	})
+}
+
+func TestBootstrapTokenPhaseIsRotationDue(t *testing.T) {
+	t.Run("false when no active token is close to expiring", func(t *testing.T) {
+		phase := &bootstrapTokenPhase{
+			RotationGracePeriod: time.Hour,
+			ActiveTokens: []kamajiv1alpha1.BootstrapTokenStatus{
+				{ID: "abcdef", Expires: &metav1.Time{Time: time.Now().Add(24 * time.Hour)}},
+			},
+		}
+
+		if phase.IsRotationDue() {
+			t.Error("expected rotation not to be due")
+		}
+	})
+
+	t.Run("true when an active token is within the grace window", func(t *testing.T) {
+		phase := &bootstrapTokenPhase{
+			RotationGracePeriod: time.Hour,
+			ActiveTokens: []kamajiv1alpha1.BootstrapTokenStatus{
+				{ID: "abcdef", Expires: &metav1.Time{Time: time.Now().Add(time.Minute)}},
+			},
+		}
+
+		if !phase.IsRotationDue() {
+			t.Error("expected rotation to be due")
+		}
+	})
+
+	t.Run("false when the active token never expires", func(t *testing.T) {
+		phase := &bootstrapTokenPhase{
+			RotationGracePeriod: time.Hour,
+			ActiveTokens:        []kamajiv1alpha1.BootstrapTokenStatus{{ID: "abcdef"}},
+		}
+
+		if phase.IsRotationDue() {
+			t.Error("expected rotation not to be due for a token without an expiration")
+		}
+	})
+}
+
+func TestRotateBootstrapTokens(t *testing.T) {
+	phase := &bootstrapTokenPhase{
+		RotationGracePeriod: time.Hour,
+		ActiveTokens: []kamajiv1alpha1.BootstrapTokenStatus{
+			{ID: "expired", Expires: &metav1.Time{Time: time.Now().Add(time.Minute)}},
+			{ID: "fresh", Expires: &metav1.Time{Time: time.Now().Add(24 * time.Hour)}},
+		},
+	}
+
+	bootstrapTokens := []bootstraptokenv1.BootstrapToken{
+		{Token: &bootstraptokenv1.BootstrapTokenString{ID: "expired"}, Description: "keep me"},
+		{Token: &bootstraptokenv1.BootstrapTokenString{ID: "fresh"}, Description: "keep me too"},
+	}
+
+	client := fake.NewSimpleClientset()
+
+	rotated := phase.rotateBootstrapTokens(client, bootstrapTokens)
+
+	if rotated[0].Token != nil {
+		t.Errorf("expected the expiring token to be reset, got %+v", rotated[0].Token)
+	}
+
+	if rotated[0].Description != "keep me" {
+		t.Errorf("expected the user-declared fields to survive rotation, got %q", rotated[0].Description)
+	}
+
+	if rotated[1].Token == nil || rotated[1].Token.ID != "fresh" {
+		t.Errorf("expected the token not yet due for rotation to be left untouched, got %+v", rotated[1].Token)
+	}
+}
+
+// checksumOf underpins drift detection: KubeadmPhase.isDrifted re-applies a phase whenever it
+// diverges from the checksum Kamaji last recorded, so it must be a stable function of the data
+// alone, independent of map iteration order.
+func TestChecksumOf(t *testing.T) {
+	a := checksumOf(map[string]string{"b": "2", "a": "1"})
+	b := checksumOf(map[string]string{"a": "1", "b": "2"})
+
+	if a != b {
+		t.Error("expected checksumOf to be independent of map iteration order")
+	}
+
+	c := checksumOf(map[string]string{"a": "1", "b": "3"})
+	if a == c {
+		t.Error("expected checksumOf to change when a value changes")
+	}
+}
+
+func TestPhaseRegistry(t *testing.T) {
+	t.Run("resolves a built-in phase by name", func(t *testing.T) {
+		phase, err := NewPhase(PhaseNameBootstrapToken)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if phase.Name() != PhaseNameBootstrapToken {
+			t.Errorf("expected phase %q, got %q", PhaseNameBootstrapToken, phase.Name())
+		}
+	})
+
+	t.Run("resolves a custom registered phase", func(t *testing.T) {
+		const name = "TestCustomPhase"
+		Register(name, func() Phase { return &uploadKubeadmConfigPhase{} })
+
+		phase, err := NewPhase(name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if phase.Name() != PhaseNameUploadConfigKubeadm {
+			t.Errorf("expected the registered factory to be used, got phase named %q", phase.Name())
+		}
+	})
+
+	t.Run("errors on an unknown name", func(t *testing.T) {
+		if _, err := NewPhase("does-not-exist"); err == nil {
+			t.Error("expected an error for an unregistered phase name")
+		}
+	})
+}
+
+// TestAddonPhasesEnabledByDefault guards against the addon phases only being enabled via CRD
+// schema defaulting: a TenantControlPlane built without going through API server admission, such
+// as in a test or controller-side code, must still get CoreDNS and kube-proxy installed.
+func TestAddonPhasesEnabledByDefault(t *testing.T) {
+	if !(&addonCoreDNSPhase{}).AddonSpec.IsEnabled() {
+		t.Error("expected a zero-valued addonCoreDNSPhase to default to enabled")
+	}
+
+	if !(&addonKubeProxyPhase{}).AddonSpec.IsEnabled() {
+		t.Error("expected a zero-valued addonKubeProxyPhase to default to enabled")
+	}
+}
+
+func TestAddonPhasesDisabledNoOp(t *testing.T) {
+	disabled := false
+	client := fake.NewSimpleClientset()
+
+	t.Run("CoreDNS", func(t *testing.T) {
+		phase := &addonCoreDNSPhase{AddonSpec: kamajiv1alpha1.KubeadmAddonSpec{Enabled: &disabled}}
+
+		if _, err := phase.Run(client, nil); err != nil {
+			t.Errorf("expected a disabled addon to no-op instead of touching the tenant cluster, got %v", err)
+		}
+	})
+
+	t.Run("kube-proxy", func(t *testing.T) {
+		phase := &addonKubeProxyPhase{AddonSpec: kamajiv1alpha1.KubeadmAddonSpec{Enabled: &disabled}}
+
+		if _, err := phase.Run(client, nil); err != nil {
+			t.Errorf("expected a disabled addon to no-op instead of touching the tenant cluster, got %v", err)
+		}
+	})
+}
+
+func TestShouldStatusBeUpdated(t *testing.T) {
+	newTenantControlPlane := func() *kamajiv1alpha1.TenantControlPlane {
+		tenantControlPlane := &kamajiv1alpha1.TenantControlPlane{}
+		tenantControlPlane.Status.KubeadmPhase.UploadConfigKubeadm.Checksum = "checksum-a"
+		tenantControlPlane.Status.KubeadmPhase.UploadConfigKubeadm.ObservedChecksum = "checksum-a"
+
+		return tenantControlPlane
+	}
+
+	t.Run("rotation due forces a re-apply under DriftPolicyIgnore, without touching observedChecksum", func(t *testing.T) {
+		r := &KubeadmPhase{Phase: &fakeDriftRotationPhase{rotationDue: true, observedChecksum: "checksum-b"}, TenantClient: fake.NewSimpleClientset()}
+		r.SetKubeadmConfigChecksum("checksum-a")
+
+		tenantControlPlane := newTenantControlPlane()
+		tenantControlPlane.Spec.Kubeadm.DriftPolicy = kamajiv1alpha1.DriftPolicyIgnore
+
+		if !r.ShouldStatusBeUpdated(context.TODO(), tenantControlPlane) {
+			t.Error("expected rotation-due to force a re-apply")
+		}
+
+		if r.observedChecksum != "" {
+			t.Error("expected isDrifted not to run under DriftPolicyIgnore")
+		}
+	})
+
+	t.Run("rotation due still refreshes observedChecksum under DriftPolicyEnforce", func(t *testing.T) {
+		r := &KubeadmPhase{
+			Phase:        &fakeDriftRotationPhase{rotationDue: true, observedChecksum: "checksum-b"},
+			TenantClient: fake.NewSimpleClientset(),
+		}
+		r.SetKubeadmConfigChecksum("checksum-a")
+
+		tenantControlPlane := newTenantControlPlane()
+		tenantControlPlane.Spec.Kubeadm.DriftPolicy = kamajiv1alpha1.DriftPolicyEnforce
+
+		if !r.ShouldStatusBeUpdated(context.TODO(), tenantControlPlane) {
+			t.Error("expected rotation-due to force a re-apply")
+		}
+
+		if r.observedChecksum != "checksum-b" {
+			t.Errorf("expected isDrifted to still run and refresh observedChecksum, got %q", r.observedChecksum)
+		}
+	})
+
+	t.Run("no trigger fires when nothing changed", func(t *testing.T) {
+		r := &KubeadmPhase{
+			Phase:        &fakeDriftRotationPhase{observedChecksum: "checksum-a"},
+			TenantClient: fake.NewSimpleClientset(),
+		}
+		r.SetKubeadmConfigChecksum("checksum-a")
+
+		tenantControlPlane := newTenantControlPlane()
+		tenantControlPlane.Spec.Kubeadm.DriftPolicy = kamajiv1alpha1.DriftPolicyEnforce
+
+		if r.ShouldStatusBeUpdated(context.TODO(), tenantControlPlane) {
+			t.Error("expected no re-apply when the desired, observed and rotation states all agree")
+		}
+	})
+
+	t.Run("tenant-side drift forces a re-apply under DriftPolicyEnforce", func(t *testing.T) {
+		r := &KubeadmPhase{
+			Phase:        &fakeDriftRotationPhase{observedChecksum: "checksum-c"},
+			TenantClient: fake.NewSimpleClientset(),
+		}
+		r.SetKubeadmConfigChecksum("checksum-a")
+
+		tenantControlPlane := newTenantControlPlane()
+		tenantControlPlane.Spec.Kubeadm.DriftPolicy = kamajiv1alpha1.DriftPolicyEnforce
+
+		if !r.ShouldStatusBeUpdated(context.TODO(), tenantControlPlane) {
+			t.Error("expected tenant-side drift to force a re-apply")
+		}
+	})
+}